@@ -0,0 +1,64 @@
+package golibraw
+
+import (
+	"context"
+	"image"
+	"sync"
+)
+
+// BatchResult is one file's outcome from ProcessBatch.
+type BatchResult struct {
+	Path  string
+	Image image.Image
+	Meta  ImgMetadata
+	Err   error
+}
+
+// ProcessBatch decodes paths concurrently, bounded by concurrency workers (or
+// ProcessorOptions.MaxConcurrency when concurrency is 0), and streams results
+// on the returned channel as they complete. Each libraw context can allocate
+// hundreds of MB, so the bound matters: a goroutine per file can OOM a host
+// on a large batch. The channel is closed once every path has been processed
+// or ctx is cancelled.
+func (p *Processor) ProcessBatch(ctx context.Context, paths []string, concurrency int) <-chan BatchResult {
+	if concurrency <= 0 {
+		concurrency = p.options.MaxConcurrency
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	out := make(chan BatchResult)
+	sem := make(chan struct{}, concurrency)
+
+	go func() {
+		defer close(out)
+
+		var wg sync.WaitGroup
+		for _, path := range paths {
+			select {
+			case <-ctx.Done():
+				wg.Wait()
+				return
+			case sem <- struct{}{}:
+			}
+
+			wg.Add(1)
+			go func(path string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				img, meta, err := p.ProcessRaw(path)
+				result := BatchResult{Path: path, Image: img, Meta: meta, Err: err}
+
+				select {
+				case out <- result:
+				case <-ctx.Done():
+				}
+			}(path)
+		}
+		wg.Wait()
+	}()
+
+	return out
+}
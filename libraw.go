@@ -10,6 +10,7 @@ package golibraw
 import "C"
 
 import (
+	"encoding/binary"
 	"fmt"
 	"image"
 	"log"
@@ -20,15 +21,30 @@ import (
 type ImgMetadata struct {
 	CaptureTimestamp int64
 	CaptureDate time.Time
-}
+	// RawFlip is libraw's raw sizes.flip value (0/3/5/6), unaffected by
+	// ProcessorOptions.AutoOrient.
+	RawFlip int
+	// Orientation is the logical rotation RawFlip implies. When AutoOrient
+	// is true the returned image has already been rotated accordingly;
+	// callers with AutoOrient false can apply it themselves.
+	Orientation Orientation
 
-type ProcessorOptions struct {}
+	CameraMake  string
+	CameraModel string
+	Lens        string
+	ISO         float64
+	ShutterSpeed time.Duration
+	Aperture    float64
+	FocalLength float64
+	// GPS is nil when the RAW file carries no GPS fix.
+	GPS          *GPSInfo
+	WhiteBalance WBInfo
+}
 
 // Processor is a stateless wrapper for libraw processing.
 // Each method creates its own libraw processor so that calls are goroutine‐safe.
 type Processor struct {
 	options ProcessorOptions
-	// TODO: add pool.Sync
 }
 
 func NewProcessor(opts ProcessorOptions) *Processor {
@@ -39,13 +55,10 @@ func freeCString(s *C.char) {
 	C.free(unsafe.Pointer(s))
 }
 
-// processFile opens the file, unpacks it, processes it, and returns:
-//  - proc: the libraw processor pointer
-//  - memImg: the pointer to the in‑memory image returned by libraw_dcraw_make_mem_image
-//  - dataSize, height, width, bits: image details
-func (p *Processor) processFile(filepath string) (proc *C.libraw_data_t, memImg *C.libraw_processed_image_t, dataSize C.uint,
-	height, width, bits C.ushort, err error) {
-
+// fileOpen initializes a libraw processor and opens filepath, without
+// unpacking or decoding it. On error fileOpen closes proc itself and returns
+// nil; otherwise the caller owns proc and must libraw_close it.
+func fileOpen(filepath string) (proc *C.libraw_data_t, err error) {
 	proc = C.libraw_init(0)
 	if proc == nil {
 		err = fmt.Errorf("failed to initialize libraw")
@@ -59,38 +72,38 @@ func (p *Processor) processFile(filepath string) (proc *C.libraw_data_t, memImg
 	if ret != 0 {
 		err = fmt.Errorf("libraw_open_file error: %s", C.GoString(C.libraw_strerror(C.int(ret))))
 		C.libraw_close(proc)
+		proc = nil
 		return
 	}
 
-	ret = C.libraw_unpack(proc)
+	return proc, nil
+}
+
+// openAndProcess unpacks, applies opts, decodes, and builds the in-memory
+// image for an already-opened libraw processor. It is the shared tail of the
+// file, reader, and buffer entry points: each opens proc differently
+// (libraw_open_file vs libraw_open_buffer) and then calls this. The caller
+// must release the returned image with clearAndClose.
+func openAndProcess(proc *C.libraw_data_t, opts ProcessorOptions) (memImg *C.libraw_processed_image_t, err error) {
+	ret := C.libraw_unpack(proc)
 	if ret != 0 {
-		err = fmt.Errorf("libraw_unpack error: %s", C.GoString(C.libraw_strerror(C.int(ret))))
-		C.libraw_close(proc)
-		return
+		return nil, fmt.Errorf("libraw_unpack error: %s", C.GoString(C.libraw_strerror(C.int(ret))))
 	}
 
+	applyParams(proc, opts)
+
 	ret = C.libraw_dcraw_process(proc)
 	if ret != 0 {
-		err = fmt.Errorf("libraw_dcraw_process error: %s", C.GoString(C.libraw_strerror(C.int(ret))))
-		C.libraw_close(proc)
-		return
+		return nil, fmt.Errorf("libraw_dcraw_process error: %s", C.GoString(C.libraw_strerror(C.int(ret))))
 	}
 
 	var makeImgErr C.int
-	// memImg is a pointer to libraw_processed_image_t.
 	memImg = C.libraw_dcraw_make_mem_image(proc, &makeImgErr)
 	if makeImgErr != 0 || memImg == nil {
-		err = fmt.Errorf("libraw_dcraw_make_mem_image error: %s", C.GoString(C.libraw_strerror(makeImgErr)))
-		C.libraw_close(proc)
-		return
+		return nil, fmt.Errorf("libraw_dcraw_make_mem_image error: %s", C.GoString(C.libraw_strerror(makeImgErr)))
 	}
 
-	dataSize = memImg.data_size
-	height = memImg.height
-	width = memImg.width
-	bits = memImg.bits
-
-	return
+	return memImg, nil
 }
 
 // clearAndClose releases the memory image and closes the processor.
@@ -101,6 +114,20 @@ func clearAndClose(proc *C.libraw_data_t, memImg *C.libraw_processed_image_t) {
 }
 
 
+// downshiftTo8Bit combines pairs of bytes into single 8-bit samples, discarding
+// the low (bits-8) bits. It is the mandatory-conversion step ProcessRaw used to
+// apply unconditionally; ProcessRaw16 skips it entirely.
+func downshiftTo8Bit(data []byte, bits int) []byte {
+	adjusted := make([]byte, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		value := uint16(data[i]) | (uint16(data[i+1]) << 8)
+		adjusted[i/2] = byte(value >> (bits - 8))
+	}
+	return adjusted
+}
+
+// ConvertToImage builds an 8-bit image.RGBA from packed 8-bit-per-channel RGB
+// data, such as the output of downshiftTo8Bit.
 func ConvertToImage(data []byte, width, height, bits int) (image.Image, error) {
     // Check if we have the expected amount of data for RGB
     expectedSize := width * height * 3 // 3 bytes per pixel for RGB
@@ -110,7 +137,7 @@ func ConvertToImage(data []byte, width, height, bits int) (image.Image, error) {
 
     // Create a new RGB image
     img := image.NewRGBA(image.Rect(0, 0, width, height))
-    
+
     // Convert the raw RGB data to RGBA
     for y := 0; y < height; y++ {
         for x := 0; x < width; x++ {
@@ -118,7 +145,7 @@ func ConvertToImage(data []byte, width, height, bits int) (image.Image, error) {
             r := data[offset]
             g := data[offset+1]
             b := data[offset+2]
-            
+
             // Set pixel in the RGBA image
             dstOffset := (y*width + x) * 4 // 4 bytes per pixel in RGBA
             img.Pix[dstOffset] = r
@@ -127,51 +154,143 @@ func ConvertToImage(data []byte, width, height, bits int) (image.Image, error) {
             img.Pix[dstOffset+3] = 255 // Alpha channel
         }
     }
-    
+
     return img, nil
 }
 
-// ProcessRaw processes a RAW file and returns an image.Image along with metadata.
+// ConvertToImage16 builds a 16-bit image.NRGBA64 from packed RGB data, keeping
+// every bit libraw produced. bits is the sample depth of data (8 or 16); 8-bit
+// input is widened by bit-replication so callers always get full-scale values.
+func ConvertToImage16(data []byte, width, height, bits int) (image.Image, error) {
+	bytesPerSample := bits / 8
+	expectedSize := width * height * 3 * bytesPerSample
+	if len(data) != expectedSize {
+		return nil, fmt.Errorf("unexpected data size: got %d, want %d", len(data), expectedSize)
+	}
+
+	img := image.NewNRGBA64(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			srcOffset := (y*width + x) * 3 * bytesPerSample
+			dstOffset := img.PixOffset(x, y)
+
+			var r, g, b uint16
+			if bits == 16 {
+				r = binary.LittleEndian.Uint16(data[srcOffset:])
+				g = binary.LittleEndian.Uint16(data[srcOffset+2:])
+				b = binary.LittleEndian.Uint16(data[srcOffset+4:])
+			} else {
+				r = uint16(data[srcOffset]) * 0x0101
+				g = uint16(data[srcOffset+1]) * 0x0101
+				b = uint16(data[srcOffset+2]) * 0x0101
+			}
+
+			binary.BigEndian.PutUint16(img.Pix[dstOffset:], r)
+			binary.BigEndian.PutUint16(img.Pix[dstOffset+2:], g)
+			binary.BigEndian.PutUint16(img.Pix[dstOffset+4:], b)
+			img.Pix[dstOffset+6] = 0xff
+			img.Pix[dstOffset+7] = 0xff
+		}
+	}
+
+	return img, nil
+}
+
+// finishImage converts a decoded libraw memory image to an 8-bit image.Image
+// and extracts metadata, applying AutoOrient if configured. It is the shared
+// tail of ProcessRaw's file, reader, and buffer variants.
+func (p *Processor) finishImage(proc *C.libraw_data_t, memImg *C.libraw_processed_image_t) (image.Image, ImgMetadata, error) {
+	dataBytes := C.GoBytes(unsafe.Pointer(&memImg.data[0]), C.int(memImg.data_size))
+	if memImg.bits > 8 {
+		dataBytes = downshiftTo8Bit(dataBytes, int(memImg.bits))
+	}
+
+	img, err := ConvertToImage(dataBytes, int(memImg.width), int(memImg.height), 8)
+	if err != nil {
+		return nil, ImgMetadata{}, fmt.Errorf("convert to image: %v", err)
+	}
+
+	meta := extractMetadata(proc)
+	if p.options.AutoOrient {
+		img = applyOrientation(img, meta.Orientation)
+	}
+
+	return img, meta, nil
+}
+
+// finishImage16 is finishImage's full-bit-depth counterpart, used by
+// ProcessRaw16.
+func (p *Processor) finishImage16(proc *C.libraw_data_t, memImg *C.libraw_processed_image_t) (image.Image, ImgMetadata, error) {
+	dataBytes := C.GoBytes(unsafe.Pointer(&memImg.data[0]), C.int(memImg.data_size))
+
+	img, err := ConvertToImage16(dataBytes, int(memImg.width), int(memImg.height), int(memImg.bits))
+	if err != nil {
+		return nil, ImgMetadata{}, fmt.Errorf("convert to image: %v", err)
+	}
+
+	meta := extractMetadata(proc)
+	if p.options.AutoOrient {
+		img = applyOrientation(img, meta.Orientation)
+	}
+
+	return img, meta, nil
+}
+
+// ProcessRaw processes a RAW file and returns an 8-bit image.Image along with
+// metadata. It discards libraw's full bit depth; use ProcessRaw16 to keep it.
 func (p *Processor) ProcessRaw(filepath string) (img image.Image, meta ImgMetadata, err error) {
-    t0 := time.Now()
+	t0 := time.Now()
 
-    proc, dataPtr, dataSize, height, width, bits, err := p.processFile(filepath)
-    if err != nil {
-        return nil, ImgMetadata{}, err
-    }
-    defer clearAndClose(proc, dataPtr)
-
-    // Convert raw bytes to Go slice
-    dataBytes := C.GoBytes(unsafe.Pointer(&dataPtr.data[0]), C.int(dataSize))
-
-    // Handle different bit depths
-    if bits > 8 {
-        // Convert higher bit depth to 8-bit
-        adjustedData := make([]byte, width*height*3)
-        for i := 0; i < len(dataBytes); i += 2 {
-            // Combine two bytes into one, shifting to 8-bit depth
-            if i+1 < len(dataBytes) {
-                value := uint16(dataBytes[i]) | (uint16(dataBytes[i+1]) << 8)
-                adjustedData[i/2] = byte(value >> (bits - 8))
-            }
-        }
-        dataBytes = adjustedData
-    }
+	proc, err := fileOpen(filepath)
+	if err != nil {
+		return nil, ImgMetadata{}, err
+	}
 
-    img, err = ConvertToImage(dataBytes, int(width), int(height), 8)
-    if err != nil {
-        return nil, ImgMetadata{}, fmt.Errorf("convert to image: %v", err)
-    }
+	memImg, err := openAndProcess(proc, p.options)
+	if err != nil {
+		C.libraw_close(proc)
+		return nil, ImgMetadata{}, err
+	}
+	defer clearAndClose(proc, memImg)
 
-    other := C.libraw_get_imgother(proc)
-    timestamp := int64(other.timestamp)
-    captureTime := time.Unix(timestamp, 0)
+	img, meta, err = p.finishImage(proc, memImg)
+	if err != nil {
+		return nil, ImgMetadata{}, err
+	}
 
-    meta = ImgMetadata{
-		CaptureTimestamp: timestamp,
-        CaptureDate: captureTime,
-    }
-    log.Printf("Processed RAW %s in %v", filepath, time.Since(t0))
-    return img, meta, nil
+	log.Printf("Processed RAW %s in %v", filepath, time.Since(t0))
+	return img, meta, nil
+}
+
+// ProcessRaw16 processes a RAW file like ProcessRaw but preserves libraw's
+// full output bit depth, returning an image.NRGBA64 instead of downshifting
+// to 8 bits per channel. It forces 16-bit output regardless of
+// ProcessorOptions.OutputBitsPerSample, since a caller using it otherwise
+// silently gets 8-bit data bit-replicated into a 16-bit image.
+func (p *Processor) ProcessRaw16(filepath string) (img image.Image, meta ImgMetadata, err error) {
+	t0 := time.Now()
+
+	proc, err := fileOpen(filepath)
+	if err != nil {
+		return nil, ImgMetadata{}, err
+	}
+
+	opts := p.options
+	opts.OutputBitsPerSample = 16
+
+	memImg, err := openAndProcess(proc, opts)
+	if err != nil {
+		C.libraw_close(proc)
+		return nil, ImgMetadata{}, err
+	}
+	defer clearAndClose(proc, memImg)
+
+	img, meta, err = p.finishImage16(proc, memImg)
+	if err != nil {
+		return nil, ImgMetadata{}, err
+	}
+
+	log.Printf("Processed RAW16 %s in %v", filepath, time.Since(t0))
+	return img, meta, nil
 }
 
@@ -1,6 +1,7 @@
 package golibraw
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"sync"
@@ -72,3 +73,27 @@ func TestConcurrentProcessRaw(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+// TestProcessBatch runs ProcessBatch over the test files and checks that
+// every path comes back with a decoded image.
+func TestProcessBatch(t *testing.T) {
+	processor := NewProcessor(NewProcessorOptions())
+
+	paths := getAllFilesInTestDir()
+	seen := make(map[string]bool, len(paths))
+
+	for result := range processor.ProcessBatch(context.Background(), paths, 2) {
+		if result.Err != nil {
+			t.Errorf("ProcessBatch: %s failed: %v", result.Path, result.Err)
+			continue
+		}
+		if result.Image == nil {
+			t.Errorf("ProcessBatch: %s returned a nil image", result.Path)
+		}
+		seen[result.Path] = true
+	}
+
+	if len(seen) != len(paths) {
+		t.Errorf("ProcessBatch: got %d results, want %d", len(seen), len(paths))
+	}
+}
@@ -0,0 +1,125 @@
+package golibraw
+
+// #include "libraw/libraw.h"
+// #include <stdlib.h>
+import "C"
+
+import (
+	"fmt"
+	"time"
+)
+
+// GPSInfo is the GPS fix embedded in a RAW file, if the camera recorded one.
+type GPSInfo struct {
+	Latitude  float64
+	Longitude float64
+	Altitude  float64
+	Timestamp time.Time
+}
+
+// WBInfo carries the white balance multipliers libraw derived for a shot.
+type WBInfo struct {
+	// CameraMultipliers are the camera's as-shot (R, G1, B, G2) multipliers.
+	CameraMultipliers [4]float32
+	// PreMultipliers are libraw's scene-average fallback multipliers.
+	PreMultipliers [4]float32
+	// AsShotTemperature is a rough Kelvin estimate derived from the B/R
+	// multiplier ratio. It is not a colorimetric conversion, just a ballpark
+	// useful for display; it is 0 when the multipliers aren't usable.
+	AsShotTemperature float64
+}
+
+// estimateColorTemp derives a rough color temperature from the ratio of the
+// blue to red camera multipliers. Lower B/R ratios correspond to warmer
+// (lower Kelvin) light; this is a ballpark, not a colorimetric conversion.
+func estimateColorTemp(camMul [4]float32) float64 {
+	if camMul[0] <= 0 || camMul[2] <= 0 {
+		return 0
+	}
+	ratio := float64(camMul[2]) / float64(camMul[0])
+	return 6500 * ratio
+}
+
+// dmsToDecimal converts a GPS degrees/minutes/seconds triplet to a signed
+// decimal degree value using the hemisphere reference byte ('S'/'W' negate,
+// everything else is positive). It takes plain Go types rather than libraw's
+// C.float/C.char so it can be unit tested without cgo.
+func dmsToDecimal(dms [3]float32, ref byte) float64 {
+	deg := float64(dms[0]) + float64(dms[1])/60 + float64(dms[2])/3600
+	if ref == 'S' || ref == 'W' {
+		deg = -deg
+	}
+	return deg
+}
+
+// extractMetadata reads camera, lens, exposure, white balance, GPS, and
+// orientation metadata off an opened libraw processor. It only needs
+// libraw_open_file to have succeeded; ReadMetadata relies on that to skip the
+// unpack/process steps ProcessRaw and ProcessRaw16 require.
+func extractMetadata(proc *C.libraw_data_t) ImgMetadata {
+	iparams := C.libraw_get_iparams(proc)
+	lensinfo := C.libraw_get_lensinfo(proc)
+	other := C.libraw_get_imgother(proc)
+	color := C.libraw_get_color(proc)
+
+	timestamp := int64(other.timestamp)
+	captureTime := time.Unix(timestamp, 0)
+
+	meta := ImgMetadata{
+		CaptureTimestamp: timestamp,
+		CaptureDate:      captureTime,
+		RawFlip:          int(proc.sizes.flip),
+		Orientation:      orientationFromFlip(int(proc.sizes.flip)),
+		CameraMake:       C.GoString(&iparams.make[0]),
+		CameraModel:      C.GoString(&iparams.model[0]),
+		Lens:             C.GoString(&lensinfo.Lens[0]),
+		ISO:              float64(other.iso_speed),
+		ShutterSpeed:     time.Duration(float64(other.shutter) * float64(time.Second)),
+		Aperture:         float64(other.aperture),
+		FocalLength:      float64(other.focal_len),
+		WhiteBalance: WBInfo{
+			CameraMultipliers: [4]float32{float32(color.cam_mul[0]), float32(color.cam_mul[1]), float32(color.cam_mul[2]), float32(color.cam_mul[3])},
+			PreMultipliers:    [4]float32{float32(color.pre_mul[0]), float32(color.pre_mul[1]), float32(color.pre_mul[2]), float32(color.pre_mul[3])},
+		},
+	}
+	meta.WhiteBalance.AsShotTemperature = estimateColorTemp(meta.WhiteBalance.CameraMultipliers)
+
+	gps := other.parsed_gps
+	if gps.gpsparsed != 0 {
+		// libraw's gpstimestamp is UTC hour/minute/second with no date of its
+		// own, so the date has to be borrowed from the capture timestamp.
+		// That timestamp must be read in UTC here: time.Unix returns it in
+		// the local zone, and pairing a local-zone date with a UTC
+		// time-of-day can land on the wrong day near midnight.
+		utcCaptureDate := captureTime.UTC()
+		meta.GPS = &GPSInfo{
+			Latitude:  dmsToDecimal([3]float32{float32(gps.latitude[0]), float32(gps.latitude[1]), float32(gps.latitude[2])}, byte(gps.latref)),
+			Longitude: dmsToDecimal([3]float32{float32(gps.longtitude[0]), float32(gps.longtitude[1]), float32(gps.longtitude[2])}, byte(gps.longref)),
+			Altitude:  float64(gps.altitude),
+			Timestamp: time.Date(utcCaptureDate.Year(), utcCaptureDate.Month(), utcCaptureDate.Day(),
+				int(gps.gpstimestamp[0]), int(gps.gpstimestamp[1]), int(gps.gpstimestamp[2]), 0, time.UTC),
+		}
+	}
+
+	return meta
+}
+
+// ReadMetadata reads a RAW file's camera, exposure, and GPS metadata without
+// unpacking or decoding pixel data, for fast indexing over large libraries.
+func (p *Processor) ReadMetadata(filepath string) (ImgMetadata, error) {
+	proc := C.libraw_init(0)
+	if proc == nil {
+		return ImgMetadata{}, fmt.Errorf("failed to initialize libraw")
+	}
+	defer C.libraw_close(proc)
+
+	cFile := C.CString(filepath)
+	defer freeCString(cFile)
+
+	ret := C.libraw_open_file(proc, cFile)
+	if ret != 0 {
+		return ImgMetadata{}, fmt.Errorf("libraw_open_file error: %s", C.GoString(C.libraw_strerror(C.int(ret))))
+	}
+
+	return extractMetadata(proc), nil
+}
@@ -0,0 +1,60 @@
+package golibraw
+
+import "testing"
+
+// TestDmsToDecimal checks the GPS degrees/minutes/seconds conversion and its
+// hemisphere sign flip.
+func TestDmsToDecimal(t *testing.T) {
+	north := dmsToDecimal([3]float32{40, 26, 46}, 'N')
+	if want := 40 + 26.0/60 + 46.0/3600; north != want {
+		t.Errorf("got %v, want %v", north, want)
+	}
+	south := dmsToDecimal([3]float32{40, 26, 46}, 'S')
+	if south != -north {
+		t.Errorf("south ref should negate: got %v, want %v", south, -north)
+	}
+}
+
+// TestReadMetadata checks the libraw_open_file-only fast path returns
+// populated camera metadata without a full decode.
+func TestReadMetadata(t *testing.T) {
+	processor := NewProcessor(NewProcessorOptions())
+
+	for _, path := range getAllFilesInTestDir() {
+		meta, err := processor.ReadMetadata(path)
+		if err != nil {
+			t.Fatalf("ReadMetadata(%s) failed: %v", path, err)
+		}
+		if meta.CameraMake == "" && meta.CameraModel == "" {
+			t.Errorf("ReadMetadata(%s) returned no camera make/model", path)
+		}
+		if meta.CaptureTimestamp == 0 {
+			t.Errorf("ReadMetadata(%s) returned no capture timestamp", path)
+		}
+	}
+}
+
+// TestReadMetadataMatchesProcessRaw checks that the fast metadata-only path
+// agrees with the metadata ProcessRaw derives during a full decode.
+func TestReadMetadataMatchesProcessRaw(t *testing.T) {
+	processor := NewProcessor(NewProcessorOptions())
+
+	for _, path := range getAllFilesInTestDir() {
+		fast, err := processor.ReadMetadata(path)
+		if err != nil {
+			t.Fatalf("ReadMetadata(%s) failed: %v", path, err)
+		}
+		_, full, err := processor.ProcessRaw(path)
+		if err != nil {
+			t.Fatalf("ProcessRaw(%s) failed: %v", path, err)
+		}
+		if fast.CameraMake != full.CameraMake || fast.CameraModel != full.CameraModel {
+			t.Errorf("%s: ReadMetadata camera %q/%q != ProcessRaw camera %q/%q",
+				path, fast.CameraMake, fast.CameraModel, full.CameraMake, full.CameraModel)
+		}
+		if fast.CaptureTimestamp != full.CaptureTimestamp {
+			t.Errorf("%s: ReadMetadata timestamp %d != ProcessRaw timestamp %d",
+				path, fast.CaptureTimestamp, full.CaptureTimestamp)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package golibraw
+
+// #include "libraw/libraw.h"
+import "C"
+
+// WhiteBalanceMode selects how libraw derives white balance multipliers.
+type WhiteBalanceMode int
+
+const (
+	WhiteBalanceCamera WhiteBalanceMode = iota
+	WhiteBalanceAuto
+	WhiteBalanceCustom
+)
+
+// DemosaicAlgorithm selects the Bayer interpolation algorithm, mapped to
+// libraw's user_qual.
+type DemosaicAlgorithm int
+
+const (
+	DemosaicAHD DemosaicAlgorithm = iota
+	DemosaicDCB
+	DemosaicDHT
+	DemosaicPPG
+	DemosaicLinear
+)
+
+// ColorSpace selects the output color space, mapped to libraw's output_color.
+type ColorSpace int
+
+const (
+	ColorSpaceSRGB ColorSpace = iota
+	ColorSpaceAdobeRGB
+	ColorSpaceWideGamut
+	ColorSpaceProPhoto
+	ColorSpaceXYZ
+)
+
+// ProcessorOptions configures the libraw pipeline used by a Processor's
+// methods. Use NewProcessorOptions for sane defaults rather than the zero
+// value.
+type ProcessorOptions struct {
+	// WhiteBalance selects camera, auto, or custom white balance.
+	WhiteBalance WhiteBalanceMode
+	// CustomWhiteBalance holds the per-channel (R, G1, B, G2) multipliers
+	// used when WhiteBalance is WhiteBalanceCustom.
+	CustomWhiteBalance [4]float32
+	// Demosaic selects the Bayer interpolation algorithm.
+	Demosaic DemosaicAlgorithm
+	// ColorSpace selects the output color space.
+	ColorSpace ColorSpace
+	// Gamma is the output gamma curve as [power, slope]. Zero value leaves
+	// libraw's default curve untouched.
+	Gamma [2]float32
+	// OutputBitsPerSample is 8 or 16; any other value falls back to 8.
+	OutputBitsPerSample int
+	// HighlightMode controls highlight recovery (libraw's highlight param);
+	// 0 means clip, leaving libraw's default behavior in place.
+	HighlightMode int
+	// NoiseThreshold is libraw's denoise threshold; 0 disables it.
+	NoiseThreshold float32
+	// HalfSize requests a half-resolution preview instead of a full decode.
+	HalfSize bool
+	// AutoOrient rotates decoded images upright using the camera's
+	// orientation metadata (libraw's sizes.flip). Defaults to true.
+	AutoOrient bool
+	// MaxConcurrency is the default worker count ProcessBatch uses when
+	// called with concurrency 0. Each libraw context can hold hundreds of MB
+	// of decoded image data, so this bound matters for memory, not just CPU.
+	MaxConcurrency int
+}
+
+// NewProcessorOptions returns a ProcessorOptions populated with libraw's
+// conventional defaults: camera white balance, AHD demosaicing, sRGB output.
+func NewProcessorOptions() ProcessorOptions {
+	return ProcessorOptions{
+		WhiteBalance:        WhiteBalanceCamera,
+		Demosaic:            DemosaicAHD,
+		ColorSpace:          ColorSpaceSRGB,
+		Gamma:               [2]float32{2.222, 4.5},
+		OutputBitsPerSample: 8,
+		AutoOrient:          true,
+		MaxConcurrency:      4,
+	}
+}
+
+// applyParams maps opts onto proc's libraw_output_params_t fields. It must be
+// called after libraw_unpack and before libraw_dcraw_process.
+func applyParams(proc *C.libraw_data_t, opts ProcessorOptions) {
+	params := &proc.params
+
+	switch opts.WhiteBalance {
+	case WhiteBalanceAuto:
+		params.use_auto_wb = 1
+	case WhiteBalanceCustom:
+		for i, m := range opts.CustomWhiteBalance {
+			params.user_mul[i] = C.float(m)
+		}
+	default:
+		params.use_camera_wb = 1
+	}
+
+	params.user_qual = C.int(demosaicToUserQual(opts.Demosaic))
+	params.output_color = C.int(colorSpaceToOutputColor(opts.ColorSpace))
+
+	if opts.Gamma != ([2]float32{}) {
+		params.gamm[0] = C.double(opts.Gamma[0])
+		params.gamm[1] = C.double(opts.Gamma[1])
+	}
+
+	if opts.OutputBitsPerSample == 16 {
+		params.output_bps = 16
+	} else {
+		params.output_bps = 8
+	}
+
+	if opts.HighlightMode != 0 {
+		params.highlight = C.int(opts.HighlightMode)
+	}
+
+	if opts.NoiseThreshold > 0 {
+		params.threshold = C.float(opts.NoiseThreshold)
+	}
+
+	if opts.HalfSize {
+		params.half_size = 1
+	}
+
+	// libraw defaults user_flip to -1, auto-rotating the pixel buffer from
+	// the same sizes.flip value applyOrientation uses. Force it off so
+	// orientation is only ever applied once, on the Go side.
+	params.user_flip = 0
+}
+
+// demosaicToUserQual maps DemosaicAlgorithm to libraw's user_qual values.
+func demosaicToUserQual(d DemosaicAlgorithm) int {
+	switch d {
+	case DemosaicLinear:
+		return 0
+	case DemosaicPPG:
+		return 2
+	case DemosaicDCB:
+		return 4
+	case DemosaicDHT:
+		return 11
+	default:
+		return 3 // AHD
+	}
+}
+
+// colorSpaceToOutputColor maps ColorSpace to libraw's output_color values.
+func colorSpaceToOutputColor(c ColorSpace) int {
+	switch c {
+	case ColorSpaceAdobeRGB:
+		return 2
+	case ColorSpaceWideGamut:
+		return 3
+	case ColorSpaceProPhoto:
+		return 4
+	case ColorSpaceXYZ:
+		return 5
+	default:
+		return 1 // sRGB
+	}
+}
@@ -0,0 +1,58 @@
+package golibraw
+
+import "testing"
+
+// TestDemosaicToUserQual checks the DemosaicAlgorithm -> libraw user_qual
+// mapping, including the AHD default for an out-of-range value.
+func TestDemosaicToUserQual(t *testing.T) {
+	cases := map[DemosaicAlgorithm]int{
+		DemosaicLinear:        0,
+		DemosaicPPG:           2,
+		DemosaicAHD:           3,
+		DemosaicDCB:           4,
+		DemosaicDHT:           11,
+		DemosaicAlgorithm(99): 3,
+	}
+	for algo, want := range cases {
+		if got := demosaicToUserQual(algo); got != want {
+			t.Errorf("demosaicToUserQual(%v) = %d, want %d", algo, got, want)
+		}
+	}
+}
+
+// TestColorSpaceToOutputColor checks the ColorSpace -> libraw output_color
+// mapping, including the sRGB default for an out-of-range value.
+func TestColorSpaceToOutputColor(t *testing.T) {
+	cases := map[ColorSpace]int{
+		ColorSpaceSRGB:      1,
+		ColorSpaceAdobeRGB:  2,
+		ColorSpaceWideGamut: 3,
+		ColorSpaceProPhoto:  4,
+		ColorSpaceXYZ:       5,
+		ColorSpace(99):      1,
+	}
+	for cs, want := range cases {
+		if got := colorSpaceToOutputColor(cs); got != want {
+			t.Errorf("colorSpaceToOutputColor(%v) = %d, want %d", cs, got, want)
+		}
+	}
+}
+
+// TestProcessRawHalfSize checks that ProcessorOptions.HalfSize is threaded
+// through to libraw and still produces a decodable image.
+func TestProcessRawHalfSize(t *testing.T) {
+	opts := NewProcessorOptions()
+	opts.HalfSize = true
+	processor := NewProcessor(opts)
+
+	for _, path := range getAllFilesInTestDir() {
+		img, _, err := processor.ProcessRaw(path)
+		if err != nil {
+			t.Fatalf("ProcessRaw with HalfSize failed: %v", err)
+		}
+		bounds := img.Bounds()
+		if bounds.Dx() <= 0 || bounds.Dy() <= 0 {
+			t.Errorf("Invalid image dimensions: %v", bounds)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package golibraw
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Orientation is the logical rotation needed to display a decoded image
+// upright, derived from libraw's sizes.flip.
+type Orientation int
+
+const (
+	OrientationNormal Orientation = iota
+	OrientationRotate180
+	OrientationRotate90CCW
+	OrientationRotate90CW
+)
+
+// String returns a short label for the orientation ("normal", "180", "90ccw", "90cw").
+func (o Orientation) String() string {
+	switch o {
+	case OrientationRotate180:
+		return "180"
+	case OrientationRotate90CCW:
+		return "90ccw"
+	case OrientationRotate90CW:
+		return "90cw"
+	default:
+		return "normal"
+	}
+}
+
+// orientationFromFlip maps libraw's sizes.flip (0/3/5/6) to an Orientation.
+func orientationFromFlip(flip int) Orientation {
+	switch flip {
+	case 3:
+		return OrientationRotate180
+	case 5:
+		return OrientationRotate90CCW
+	case 6:
+		return OrientationRotate90CW
+	default:
+		return OrientationNormal
+	}
+}
+
+// applyOrientation rotates img to upright according to orientation, copying
+// pixels directly rather than pulling in an imaging library.
+func applyOrientation(img image.Image, orientation Orientation) image.Image {
+	switch orientation {
+	case OrientationRotate180:
+		return rotate180(img)
+	case OrientationRotate90CCW:
+		return rotate90CCW(img)
+	case OrientationRotate90CW:
+		return rotate90CW(img)
+	default:
+		return img
+	}
+}
+
+// newImageLike allocates a draw.Image of the same concrete pixel format as
+// src (image.NRGBA64 for 16-bit output, image.RGBA otherwise) at size w x h.
+func newImageLike(src image.Image, w, h int) draw.Image {
+	if _, ok := src.(*image.NRGBA64); ok {
+		return image.NewNRGBA64(image.Rect(0, 0, w, h))
+	}
+	return image.NewRGBA(image.Rect(0, 0, w, h))
+}
+
+func rotate180(src image.Image) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	dst := newImageLike(src, w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			dst.Set(w-1-x, h-1-y, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+func rotate90CCW(src image.Image) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	dst := newImageLike(src, h, w)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			dst.Set(y, w-1-x, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+func rotate90CW(src image.Image) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	dst := newImageLike(src, h, w)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			dst.Set(h-1-y, x, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,71 @@
+package golibraw
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// newStripe builds a 2x1 RGBA image with a red pixel at x=0 and a green
+// pixel at x=1, so rotations can be checked against a known layout.
+func newStripe() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	img.Set(1, 0, color.RGBA{G: 255, A: 255})
+	return img
+}
+
+func isRed(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return r > 0 && g == 0 && b == 0
+}
+
+func isGreen(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return g > 0 && r == 0 && b == 0
+}
+
+// TestApplyOrientation checks that each flip case rotates a known fixture
+// exactly once, landing pixels where a single rotation (not a double
+// rotation, which the user_flip override in applyParams guards against) would.
+func TestApplyOrientation(t *testing.T) {
+	t.Run("normal", func(t *testing.T) {
+		out := applyOrientation(newStripe(), OrientationNormal)
+		if b := out.Bounds(); b.Dx() != 2 || b.Dy() != 1 {
+			t.Fatalf("unexpected bounds: %v", b)
+		}
+		if !isRed(out.At(0, 0)) || !isGreen(out.At(1, 0)) {
+			t.Errorf("normal orientation should leave pixels untouched")
+		}
+	})
+
+	t.Run("180", func(t *testing.T) {
+		out := applyOrientation(newStripe(), OrientationRotate180)
+		if b := out.Bounds(); b.Dx() != 2 || b.Dy() != 1 {
+			t.Fatalf("unexpected bounds: %v", b)
+		}
+		if !isGreen(out.At(0, 0)) || !isRed(out.At(1, 0)) {
+			t.Errorf("180 rotation should reverse the stripe exactly once")
+		}
+	})
+
+	t.Run("90ccw", func(t *testing.T) {
+		out := applyOrientation(newStripe(), OrientationRotate90CCW)
+		if b := out.Bounds(); b.Dx() != 1 || b.Dy() != 2 {
+			t.Fatalf("unexpected bounds: %v", b)
+		}
+		if !isGreen(out.At(0, 0)) || !isRed(out.At(0, 1)) {
+			t.Errorf("90ccw rotation landed pixels in the wrong place")
+		}
+	})
+
+	t.Run("90cw", func(t *testing.T) {
+		out := applyOrientation(newStripe(), OrientationRotate90CW)
+		if b := out.Bounds(); b.Dx() != 1 || b.Dy() != 2 {
+			t.Fatalf("unexpected bounds: %v", b)
+		}
+		if !isRed(out.At(0, 0)) || !isGreen(out.At(0, 1)) {
+			t.Errorf("90cw rotation landed pixels in the wrong place")
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package golibraw
+
+// #include "libraw/libraw.h"
+// #include <stdlib.h>
+import "C"
+
+import (
+	"fmt"
+	"image"
+	"io"
+)
+
+// bufferOpen initializes a libraw processor and opens data as an in-memory
+// RAW file via libraw_open_buffer. The returned cleanup function frees the C
+// buffer backing data; call it once libraw_close (or clearAndClose) has run,
+// not before, since libraw keeps a reference to it until then.
+func bufferOpen(data []byte) (proc *C.libraw_data_t, cleanup func(), err error) {
+	proc = C.libraw_init(0)
+	if proc == nil {
+		err = fmt.Errorf("failed to initialize libraw")
+		return
+	}
+
+	ptr := C.CBytes(data)
+	cleanup = func() { C.free(ptr) }
+
+	ret := C.libraw_open_buffer(proc, ptr, C.size_t(len(data)))
+	if ret != 0 {
+		err = fmt.Errorf("libraw_open_buffer error: %s", C.GoString(C.libraw_strerror(C.int(ret))))
+		C.libraw_close(proc)
+		cleanup()
+		proc, cleanup = nil, nil
+		return
+	}
+
+	return proc, cleanup, nil
+}
+
+// ProcessRawBytes decodes RAW data held entirely in memory, for callers that
+// already have the file's bytes (e.g. fetched from S3 or an HTTP upload) and
+// would otherwise have to write a temp file just to call ProcessRaw.
+func (p *Processor) ProcessRawBytes(data []byte) (img image.Image, meta ImgMetadata, err error) {
+	proc, cleanup, err := bufferOpen(data)
+	if err != nil {
+		return nil, ImgMetadata{}, err
+	}
+
+	memImg, err := openAndProcess(proc, p.options)
+	if err != nil {
+		C.libraw_close(proc)
+		cleanup()
+		return nil, ImgMetadata{}, err
+	}
+	defer cleanup()
+	defer clearAndClose(proc, memImg)
+
+	return p.finishImage(proc, memImg)
+}
+
+// ProcessRawReader decodes RAW data read from r, buffering it in memory and
+// delegating to ProcessRawBytes.
+func (p *Processor) ProcessRawReader(r io.Reader) (image.Image, ImgMetadata, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, ImgMetadata{}, fmt.Errorf("read RAW data: %v", err)
+	}
+	return p.ProcessRawBytes(data)
+}
@@ -0,0 +1,63 @@
+package golibraw
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestProcessRawBytes checks that decoding from an in-memory buffer produces
+// the same dimensions and metadata as decoding the same file from disk.
+func TestProcessRawBytes(t *testing.T) {
+	processor := NewProcessor(NewProcessorOptions())
+
+	for _, path := range getAllFilesInTestDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) failed: %v", path, err)
+		}
+
+		wantImg, wantMeta, err := processor.ProcessRaw(path)
+		if err != nil {
+			t.Fatalf("ProcessRaw(%s) failed: %v", path, err)
+		}
+
+		gotImg, gotMeta, err := processor.ProcessRawBytes(data)
+		if err != nil {
+			t.Fatalf("ProcessRawBytes(%s) failed: %v", path, err)
+		}
+
+		if gotImg.Bounds() != wantImg.Bounds() {
+			t.Errorf("%s: ProcessRawBytes bounds %v != ProcessRaw bounds %v", path, gotImg.Bounds(), wantImg.Bounds())
+		}
+		if gotMeta.CaptureTimestamp != wantMeta.CaptureTimestamp {
+			t.Errorf("%s: ProcessRawBytes timestamp %d != ProcessRaw timestamp %d", path, gotMeta.CaptureTimestamp, wantMeta.CaptureTimestamp)
+		}
+	}
+}
+
+// TestProcessRawReader checks ProcessRawReader against a bytes.Reader over
+// each test file's contents, mirroring
+// how an HTTP or S3 caller would stream a RAW file in without writing it to
+// disk first.
+func TestProcessRawReader(t *testing.T) {
+	processor := NewProcessor(NewProcessorOptions())
+
+	for _, path := range getAllFilesInTestDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) failed: %v", path, err)
+		}
+
+		img, meta, err := processor.ProcessRawReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("ProcessRawReader(%s) failed: %v", path, err)
+		}
+		if bounds := img.Bounds(); bounds.Dx() <= 0 || bounds.Dy() <= 0 {
+			t.Errorf("ProcessRawReader(%s) returned invalid dimensions: %v", path, bounds)
+		}
+		if meta.CaptureTimestamp == 0 {
+			t.Errorf("ProcessRawReader(%s) returned invalid metadata", path)
+		}
+	}
+}
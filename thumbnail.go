@@ -0,0 +1,116 @@
+package golibraw
+
+// #include "libraw/libraw.h"
+// #include <stdlib.h>
+import "C"
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"unsafe"
+)
+
+// ThumbnailInfo describes the embedded preview ExtractThumbnail returned.
+type ThumbnailInfo struct {
+	Width  int
+	Height int
+	// Format is "jpeg" or "bitmap", matching how the camera embedded it.
+	Format string
+}
+
+// openThumb opens filepath and unpacks its embedded thumbnail, returning the
+// libraw processor and the in-memory thumbnail image. The caller must
+// release both with libraw_dcraw_clear_mem and libraw_close.
+func openThumb(filepath string) (proc *C.libraw_data_t, thumb *C.libraw_processed_image_t, err error) {
+	proc = C.libraw_init(0)
+	if proc == nil {
+		err = fmt.Errorf("failed to initialize libraw")
+		return
+	}
+
+	cFile := C.CString(filepath)
+	defer freeCString(cFile)
+
+	ret := C.libraw_open_file(proc, cFile)
+	if ret != 0 {
+		err = fmt.Errorf("libraw_open_file error: %s", C.GoString(C.libraw_strerror(C.int(ret))))
+		C.libraw_close(proc)
+		return
+	}
+
+	ret = C.libraw_unpack_thumb(proc)
+	if ret != 0 {
+		err = fmt.Errorf("libraw_unpack_thumb error: %s", C.GoString(C.libraw_strerror(C.int(ret))))
+		C.libraw_close(proc)
+		return
+	}
+
+	var thumbErr C.int
+	thumb = C.libraw_dcraw_make_mem_thumb(proc, &thumbErr)
+	if thumbErr != 0 || thumb == nil {
+		err = fmt.Errorf("libraw_dcraw_make_mem_thumb error: %s", C.GoString(C.libraw_strerror(thumbErr)))
+		C.libraw_close(proc)
+		return
+	}
+
+	return
+}
+
+// ExtractThumbnail decodes the RAW file's embedded preview, which is orders
+// of magnitude faster than a full ProcessRaw decode. Cameras embed either a
+// JPEG or a raw bitmap; both are returned as an image.Image.
+func (p *Processor) ExtractThumbnail(filepath string) (image.Image, ThumbnailInfo, error) {
+	proc, thumb, err := openThumb(filepath)
+	if err != nil {
+		return nil, ThumbnailInfo{}, err
+	}
+	defer C.libraw_dcraw_clear_mem(thumb)
+	defer C.libraw_close(proc)
+
+	info := ThumbnailInfo{
+		Width:  int(thumb.width),
+		Height: int(thumb.height),
+	}
+
+	data := C.GoBytes(unsafe.Pointer(&thumb.data[0]), C.int(thumb.data_size))
+
+	switch thumb._type {
+	case C.LIBRAW_IMAGE_JPEG:
+		info.Format = "jpeg"
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, ThumbnailInfo{}, fmt.Errorf("decode thumbnail jpeg: %v", err)
+		}
+		return img, info, nil
+	case C.LIBRAW_IMAGE_BITMAP:
+		info.Format = "bitmap"
+		img, err := ConvertToImage(data, info.Width, info.Height, 8)
+		if err != nil {
+			return nil, ThumbnailInfo{}, fmt.Errorf("convert thumbnail: %v", err)
+		}
+		return img, info, nil
+	default:
+		return nil, ThumbnailInfo{}, fmt.Errorf("unsupported thumbnail type: %d", thumb._type)
+	}
+}
+
+// ExtractThumbnailBytes returns the RAW file's embedded JPEG thumbnail
+// untouched, for zero-copy serving over HTTP. It errors if the camera
+// embedded a raw bitmap preview instead of a JPEG; use ExtractThumbnail for
+// that case.
+func (p *Processor) ExtractThumbnailBytes(filepath string) ([]byte, error) {
+	proc, thumb, err := openThumb(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer C.libraw_dcraw_clear_mem(thumb)
+	defer C.libraw_close(proc)
+
+	if thumb._type != C.LIBRAW_IMAGE_JPEG {
+		return nil, fmt.Errorf("embedded thumbnail is not a JPEG (type %d)", thumb._type)
+	}
+
+	return C.GoBytes(unsafe.Pointer(&thumb.data[0]), C.int(thumb.data_size)), nil
+}
@@ -0,0 +1,58 @@
+package golibraw
+
+import "testing"
+
+// TestExtractThumbnail decodes each test file's embedded preview and checks
+// that it reports sane dimensions and a recognized format.
+func TestExtractThumbnail(t *testing.T) {
+	processor := NewProcessor(NewProcessorOptions())
+
+	for _, path := range getAllFilesInTestDir() {
+		img, info, err := processor.ExtractThumbnail(path)
+		if err != nil {
+			t.Fatalf("ExtractThumbnail(%s) failed: %v", path, err)
+		}
+		if img == nil {
+			t.Fatalf("ExtractThumbnail(%s) returned a nil image", path)
+		}
+		if info.Width <= 0 || info.Height <= 0 {
+			t.Errorf("ExtractThumbnail(%s) returned invalid dimensions: %+v", path, info)
+		}
+		if info.Format != "jpeg" && info.Format != "bitmap" {
+			t.Errorf("ExtractThumbnail(%s) returned unexpected format: %q", path, info.Format)
+		}
+
+		bounds := img.Bounds()
+		if bounds.Dx() != info.Width || bounds.Dy() != info.Height {
+			t.Errorf("ExtractThumbnail(%s) image bounds %v don't match ThumbnailInfo %+v", path, bounds, info)
+		}
+	}
+}
+
+// TestExtractThumbnailBytes checks the zero-copy JPEG path, skipping files
+// whose embedded preview isn't a JPEG.
+func TestExtractThumbnailBytes(t *testing.T) {
+	processor := NewProcessor(NewProcessorOptions())
+
+	for _, path := range getAllFilesInTestDir() {
+		_, info, err := processor.ExtractThumbnail(path)
+		if err != nil {
+			t.Fatalf("ExtractThumbnail(%s) failed: %v", path, err)
+		}
+		if info.Format != "jpeg" {
+			continue
+		}
+
+		data, err := processor.ExtractThumbnailBytes(path)
+		if err != nil {
+			t.Fatalf("ExtractThumbnailBytes(%s) failed: %v", path, err)
+		}
+		if len(data) == 0 {
+			t.Errorf("ExtractThumbnailBytes(%s) returned no data", path)
+		}
+		// A JPEG stream starts with the SOI marker 0xFFD8.
+		if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+			t.Errorf("ExtractThumbnailBytes(%s) did not return a JPEG stream", path)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package golibraw
+
+import (
+	"fmt"
+	"image"
+	"io"
+
+	"golang.org/x/image/tiff"
+)
+
+// TIFFCompression selects the lossless compression scheme WriteTIFF applies.
+type TIFFCompression int
+
+const (
+	TIFFCompressionNone TIFFCompression = iota
+	TIFFCompressionLZW
+	TIFFCompressionDeflate
+)
+
+// TIFFOptions configures WriteTIFF.
+type TIFFOptions struct {
+	Compression TIFFCompression
+}
+
+// WriteTIFF encodes img as a TIFF, writing 16-bit-per-channel samples when img
+// is an image.NRGBA64 (e.g. from ProcessRaw16) and 8-bit samples otherwise.
+//
+// TIFFCompressionLZW is rejected: golang.org/x/image/tiff can decode LZW but
+// never implemented an LZW encoder, so passing it through would only surface
+// a confusing "unsupported compression" error from the decoder's own
+// vocabulary. Use TIFFCompressionDeflate for a comparably-effective lossless
+// alternative that the encoder actually supports.
+func WriteTIFF(w io.Writer, img image.Image, opts TIFFOptions) error {
+	var compression tiff.CompressionType
+	switch opts.Compression {
+	case TIFFCompressionLZW:
+		return fmt.Errorf("golibraw: TIFFCompressionLZW is not supported for encoding (golang.org/x/image/tiff only decodes LZW); use TIFFCompressionDeflate instead")
+	case TIFFCompressionDeflate:
+		compression = tiff.Deflate
+	default:
+		compression = tiff.Uncompressed
+	}
+
+	return tiff.Encode(w, img, &tiff.Options{Compression: compression})
+}
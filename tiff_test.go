@@ -0,0 +1,102 @@
+package golibraw
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/tiff"
+)
+
+// TestConvertToImage16 checks that 16-bit-per-channel input is carried
+// through to the NRGBA64 pixels untouched.
+func TestConvertToImage16(t *testing.T) {
+	data := make([]byte, 2*1*3*2)
+	binary.LittleEndian.PutUint16(data[0:], 0x1234)
+	binary.LittleEndian.PutUint16(data[2:], 0x5678)
+	binary.LittleEndian.PutUint16(data[4:], 0x9abc)
+
+	img, err := ConvertToImage16(data, 2, 1, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nrgba, ok := img.(*image.NRGBA64)
+	if !ok {
+		t.Fatalf("want *image.NRGBA64, got %T", img)
+	}
+	c := nrgba.NRGBA64At(0, 0)
+	if c.R != 0x1234 || c.G != 0x5678 || c.B != 0x9abc || c.A != 0xffff {
+		t.Errorf("got %#v", c)
+	}
+}
+
+// TestConvertToImage16BitReplication checks that 8-bit input is widened by
+// bit replication rather than zero-padded, so full-scale white stays white.
+func TestConvertToImage16BitReplication(t *testing.T) {
+	data := []byte{0x12, 0x34, 0x56}
+	img, err := ConvertToImage16(data, 1, 1, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := img.(*image.NRGBA64).NRGBA64At(0, 0)
+	if c.R != 0x1212 || c.G != 0x3434 || c.B != 0x5656 {
+		t.Errorf("got %#v", c)
+	}
+}
+
+// TestWriteTIFFRoundTrip writes and re-decodes an NRGBA64 image under both
+// supported compression modes.
+func TestWriteTIFFRoundTrip(t *testing.T) {
+	src := image.NewNRGBA64(image.Rect(0, 0, 3, 2))
+	src.Set(1, 1, color.NRGBA64{R: 0x1111, G: 0x2222, B: 0x3333, A: 0xffff})
+
+	for _, comp := range []TIFFCompression{TIFFCompressionNone, TIFFCompressionDeflate} {
+		var buf bytes.Buffer
+		if err := WriteTIFF(&buf, src, TIFFOptions{Compression: comp}); err != nil {
+			t.Fatalf("compression %d: %v", comp, err)
+		}
+		decoded, err := tiff.Decode(&buf)
+		if err != nil {
+			t.Fatalf("compression %d: decode: %v", comp, err)
+		}
+		if decoded.Bounds() != src.Bounds() {
+			t.Errorf("compression %d: bounds mismatch: %v vs %v", comp, decoded.Bounds(), src.Bounds())
+		}
+	}
+}
+
+// TestWriteTIFFLZWUnsupported checks that requesting LZW fails loudly
+// instead of silently falling back to another compression.
+func TestWriteTIFFLZWUnsupported(t *testing.T) {
+	src := image.NewNRGBA64(image.Rect(0, 0, 1, 1))
+	var buf bytes.Buffer
+	if err := WriteTIFF(&buf, src, TIFFOptions{Compression: TIFFCompressionLZW}); err == nil {
+		t.Fatal("expected an error for TIFFCompressionLZW")
+	}
+}
+
+// TestProcessRaw16 uses ProcessRaw16 to decode each test RAW file at full bit
+// depth and checks the result is a non-degenerate NRGBA64 image.
+func TestProcessRaw16(t *testing.T) {
+	processor := NewProcessor(NewProcessorOptions())
+
+	for _, path := range getAllFilesInTestDir() {
+		img, meta, err := processor.ProcessRaw16(path)
+		if err != nil {
+			t.Fatalf("ProcessRaw16 failed: %v", err)
+		}
+		if _, ok := img.(*image.NRGBA64); !ok {
+			t.Fatalf("ProcessRaw16 returned %T, want *image.NRGBA64", img)
+		}
+
+		bounds := img.Bounds()
+		if bounds.Dx() <= 0 || bounds.Dy() <= 0 {
+			t.Errorf("Invalid image dimensions: %v", bounds)
+		}
+		if meta.CaptureTimestamp == 0 {
+			t.Error("ProcessRaw16 returned invalid metadata")
+		}
+	}
+}